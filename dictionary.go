@@ -0,0 +1,209 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import (
+	"bufio"
+	"embed"
+	"encoding/hex"
+	"hash"
+	"os"
+	"strings"
+	"sync"
+)
+
+//go:embed dict/fallback.txt
+var fallbackDictFS embed.FS
+
+// Dictionary is a loadable set of known or weak passwords, used to
+// catch dictionary-based choices that a simple substring search
+// misses. The zero value loads only the embedded fallback list.
+type Dictionary struct {
+	// MinDist enables fuzzy matching: a password within this edit
+	// distance of a dictionary word is reported as a match. Zero
+	// disables fuzzy matching, so only exact (post-normalization)
+	// hits are reported.
+	MinDist int
+
+	// Hashers, when set, causes the dictionary to also index every
+	// word's digest under each of these hash.Hash constructors, and
+	// to match a password whose hex digest appears as a line of a
+	// loaded wordlist, so hashed leak lists (e.g. HIBP SHA-1 dumps)
+	// can be loaded and matched against without ever holding their
+	// plaintext. Each
+	// constructor is called to produce a fresh hash.Hash per word,
+	// since hash.Hash is stateful and not safe for concurrent reuse.
+	Hashers []func() hash.Hash
+
+	paths  []string
+	once   sync.Once
+	words  map[string]struct{}
+	hashes map[string]struct{}
+}
+
+// NewDictionary returns a Dictionary that lazily loads the given
+// wordlist paths, plus an embedded fallback list, on first use.
+func NewDictionary(paths ...string) *Dictionary {
+	return &Dictionary{paths: paths}
+}
+
+// leetReplacer undoes the small set of leetspeak substitutions
+// people commonly use to dodge naive dictionary checks.
+var leetReplacer = strings.NewReplacer(
+	"4", "a",
+	"3", "e",
+	"1", "i",
+	"0", "o",
+	"@", "a",
+	"$", "s",
+)
+
+// normalizePassword lowercases password and reverses common
+// leetspeak substitutions so "P4$$w0rd" lines up with "password".
+func normalizePassword(password string) string {
+	return leetReplacer.Replace(strings.ToLower(password))
+}
+
+// ensureLoaded populates words and hashes exactly once.
+func (d *Dictionary) ensureLoaded() {
+	d.once.Do(func() {
+		d.words = make(map[string]struct{})
+		d.hashes = make(map[string]struct{})
+
+		for _, path := range d.paths {
+			file, err := os.Open(path)
+			if err != nil {
+				continue
+			}
+			d.addWords(bufio.NewScanner(file))
+			file.Close()
+		}
+
+		if fallback, err := fallbackDictFS.Open("dict/fallback.txt"); err == nil {
+			d.addWords(bufio.NewScanner(fallback))
+			fallback.Close()
+		}
+	})
+}
+
+// addWords reads one word per line from scanner, normalizing and
+// indexing each into words and, if Hashers is set, hashes.
+func (d *Dictionary) addWords(scanner *bufio.Scanner) {
+	for scanner.Scan() {
+		word := normalizePassword(strings.TrimSpace(scanner.Text()))
+		if word == "" {
+			continue
+		}
+		d.words[word] = struct{}{}
+		for _, newHasher := range d.Hashers {
+			h := newHasher()
+			h.Write([]byte(word))
+			d.hashes[hex.EncodeToString(h.Sum(nil))] = struct{}{}
+		}
+	}
+}
+
+// Check reports whether password matches, or fuzzily resembles, an
+// entry in the dictionary. match is the closest dictionary word
+// found, distance is its edit distance from the normalized password
+// (0 for an exact or hashed hit), and ok reports whether any match
+// was found. When the hit is a hex digest listed in a wordlist, match
+// is the normalized password rather than the digest.
+func (d *Dictionary) Check(password string) (match string, distance int, ok bool) {
+	d.ensureLoaded()
+
+	normalized := normalizePassword(password)
+	if _, found := d.words[normalized]; found {
+		return normalized, 0, true
+	}
+	for _, newHasher := range d.Hashers {
+		h := newHasher()
+		h.Write([]byte(normalized))
+		digest := hex.EncodeToString(h.Sum(nil))
+		if _, found := d.hashes[digest]; found {
+			return normalized, 0, true
+		}
+		if _, found := d.words[normalizePassword(digest)]; found {
+			return normalized, 0, true
+		}
+	}
+
+	if d.MinDist <= 0 {
+		return "", 0, false
+	}
+
+	bestDist := -1
+	bestWord := ""
+	for word := range d.words {
+		if abs(len(word)-len(normalized)) > d.MinDist {
+			continue
+		}
+		dist := levenshteinDistance(normalized, word)
+		if bestDist == -1 || dist < bestDist {
+			bestDist, bestWord = dist, word
+		}
+	}
+	if bestDist >= 0 && bestDist <= d.MinDist {
+		return bestWord, bestDist, true
+	}
+	return "", 0, false
+}
+
+// levenshteinDistance computes the Wagner-Fischer edit distance
+// between a and b.
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// defaultDictionary backs the package-level ProcessPassword and
+// ZxcvbnLikeScore wrappers. MinDist is non-zero so DictionaryBased
+// actually reflects fuzzy hits (close misspellings, added digits,
+// etc.) as intended, not just exact post-normalization matches.
+var defaultDictionary = &Dictionary{MinDist: 2}
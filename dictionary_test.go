@@ -0,0 +1,76 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"hash"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestDictionaryCheckExactAndFuzzy(t *testing.T) {
+	d := &Dictionary{MinDist: 1}
+
+	if _, _, ok := d.Check("password"); !ok {
+		t.Fatalf("expected exact dictionary hit for %q", "password")
+	}
+	if match, dist, ok := d.Check("passw0rd"); !ok || match != "password" || dist != 0 {
+		t.Fatalf("expected leetspeak-normalized hit, got match=%q dist=%d ok=%v", match, dist, ok)
+	}
+	if match, dist, ok := d.Check("passwore"); !ok || match != "password" || dist != 1 {
+		t.Fatalf("expected fuzzy hit within MinDist, got match=%q dist=%d ok=%v", match, dist, ok)
+	}
+	if _, _, ok := d.Check("xqjklmzvbnthisisnotadictionaryword"); ok {
+		t.Fatalf("expected no match for unrelated string")
+	}
+}
+
+func TestDictionaryCheckHashed(t *testing.T) {
+	d := &Dictionary{Hashers: []func() hash.Hash{sha1.New}}
+
+	if _, _, ok := d.Check("password"); !ok {
+		t.Fatalf("expected dictionary hit for %q with Hashers set", "password")
+	}
+
+	// A wordlist holding only the SHA-1 digest of a word matches that word.
+	sum := sha1.Sum([]byte("correcthorse"))
+	path := filepath.Join(t.TempDir(), "sha1.txt")
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(sum[:])+"\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	hashed := NewDictionary(path)
+	hashed.Hashers = []func() hash.Hash{sha1.New}
+	if _, _, ok := hashed.Check("correcthorse"); !ok {
+		t.Fatalf("expected hashed dictionary hit for %q", "correcthorse")
+	}
+}
+
+func TestDictionaryCheckConcurrent(t *testing.T) {
+	d := &Dictionary{Hashers: []func() hash.Hash{sha1.New}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.Check("password")
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,151 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// keyboardWalks lists common keyboard-row sequences checked, along
+// with the alphabet and digit runs, when scoring for predictable
+// patterns.
+var keyboardWalks = []string{
+	lowerAlphabet,
+	digitAlphabet,
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+}
+
+// Entropy computes the Shannon entropy of p.Pass, in bits, as
+// len(p.Pass) * log2(poolSize), where poolSize is the sum of the
+// character-class alphabets actually used by the password.
+func Entropy(p *Password) float64 {
+	var hasLower, hasUpper, hasDigit, hasSpecial bool
+	for _, r := range p.Pass {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSpecial = true
+		}
+	}
+
+	pool := 0
+	if hasLower {
+		pool += len(lowerAlphabet)
+	}
+	if hasUpper {
+		pool += len(upperAlphabet)
+	}
+	if hasDigit {
+		pool += len(digitAlphabet)
+	}
+	if hasSpecial {
+		pool += len(specialAlphabet)
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(p.Length) * math.Log2(float64(pool))
+}
+
+// ZxcvbnLikeScore estimates a more realistic strength than Entropy by
+// subtracting bits for the predictable patterns real attackers try
+// first: repeated characters, sequential runs ("abcd", "1234"),
+// keyboard walks ("qwerty"), and dictionary hits.
+func ZxcvbnLikeScore(p *Password) float64 {
+	bits := Entropy(p)
+	lowered := strings.ToLower(p.Pass)
+
+	bits -= repeatPenalty(lowered)
+	bits -= walkPenalty(lowered)
+	if _, _, ok := defaultDictionary.Check(p.Pass); ok {
+		bits -= 20
+	}
+	if bits < 0 {
+		bits = 0
+	}
+	return bits
+}
+
+// repeatPenalty docks 4 bits for every character beyond the second in
+// a run of 3 or more identical characters, e.g. "aaaa" costs 8 bits.
+func repeatPenalty(s string) float64 {
+	penalty := 0.0
+	run := 1
+	for i := 1; i <= len(s); i++ {
+		if i < len(s) && s[i] == s[i-1] {
+			run++
+			continue
+		}
+		if run >= 3 {
+			penalty += float64(run-2) * 4
+		}
+		run = 1
+	}
+	return penalty
+}
+
+// walkPenalty docks 4 bits per character of the longest sequential
+// run or keyboard walk (forwards or backwards) found in s.
+func walkPenalty(s string) float64 {
+	longest := 0
+	for _, walk := range keyboardWalks {
+		for _, candidate := range []string{walk, reverseString(walk)} {
+			for i := 0; i < len(candidate); i++ {
+				for j := i + 3; j <= len(candidate); j++ {
+					substr := candidate[i:j]
+					if len(substr) > longest && strings.Contains(s, substr) {
+						longest = len(substr)
+					}
+				}
+			}
+		}
+	}
+	return float64(longest) * 4
+}
+
+func reverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// EntropyRating maps a bit count to a five-level qualitative rating,
+// as an alternative to the class-count Score heuristic (still used by
+// ComplexityRating) that rated "Password1!" as Very Strong.
+func EntropyRating(bits float64) string {
+	switch {
+	case bits < 28:
+		return "Horrible"
+	case bits < 36:
+		return "Weak"
+	case bits < 60:
+		return "Medium"
+	case bits < 128:
+		return "Strong"
+	default:
+		return "Very Strong"
+	}
+}
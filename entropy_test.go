@@ -0,0 +1,54 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import "testing"
+
+func TestEntropyRating(t *testing.T) {
+	tests := []struct {
+		bits float64
+		want string
+	}{
+		{0, "Horrible"},
+		{27.9, "Horrible"},
+		{28, "Weak"},
+		{35.9, "Weak"},
+		{36, "Medium"},
+		{59.9, "Medium"},
+		{60, "Strong"},
+		{127.9, "Strong"},
+		{128, "Very Strong"},
+	}
+	for _, tt := range tests {
+		if got := EntropyRating(tt.bits); got != tt.want {
+			t.Errorf("EntropyRating(%v) = %q, want %q", tt.bits, got, tt.want)
+		}
+	}
+}
+
+func TestZxcvbnLikeScoreDowngradesPatterns(t *testing.T) {
+	p := New("Password1!")
+	entropy := Entropy(p)
+	score := ZxcvbnLikeScore(p)
+	if score >= entropy {
+		t.Fatalf("expected ZxcvbnLikeScore (%v) to be lower than raw Entropy (%v) for a pattern-heavy password", score, entropy)
+	}
+}
+
+func TestZxcvbnLikeScoreNeverNegative(t *testing.T) {
+	if score := ZxcvbnLikeScore(New("aaaaaaaaaa")); score < 0 {
+		t.Fatalf("expected score to be clamped at 0, got %v", score)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import (
+	"bufio"
+	"crypto/rand"
+	"embed"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+//go:embed wordlist/wordlist.txt
+var defaultWordlistFS embed.FS
+
+var (
+	defaultWordlistOnce  sync.Once
+	defaultWordlistWords []string
+)
+
+// defaultWordlist lazily loads the embedded fallback wordlist used by
+// GeneratePassphrase when no wordlist is supplied. It is a sample of
+// common English words spanning the alphabet, not the official EFF
+// long wordlist (which is 7776 words and isn't vendored here) -
+// callers who need that exact word space and its 12.9 bits/word
+// should pass their own wordlist.
+func defaultWordlist() []string {
+	defaultWordlistOnce.Do(func() {
+		file, err := defaultWordlistFS.Open("wordlist/wordlist.txt")
+		if err != nil {
+			return
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			word := strings.TrimSpace(scanner.Text())
+			if word != "" {
+				defaultWordlistWords = append(defaultWordlistWords, word)
+			}
+		}
+	})
+	return defaultWordlistWords
+}
+
+// ErrEntropyTooLow is returned by the generators below when the
+// candidate they produced doesn't clear the caller's requested
+// minimum number of bits of entropy.
+var ErrEntropyTooLow = errors.New("GoPasswordUtilities: generated password doesn't meet the minimum entropy threshold")
+
+const (
+	consonants = "bcdfghjklmnpqrstvwxyz"
+	vowels     = "aeiou"
+)
+
+// GeneratePassphrase builds a Diceware-style passphrase of wordCount
+// words drawn uniformly from wordlist (the embedded default wordlist
+// if wordlist is nil - see defaultWordlist for what that is and
+// isn't) and joined with sep. It returns ErrEntropyTooLow if minBits
+// is positive and the passphrase doesn't reach it.
+func GeneratePassphrase(wordCount int, sep string, wordlist []string, minBits float64) (*Password, error) {
+	if wordCount <= 0 {
+		return nil, errors.New("GoPasswordUtilities: wordCount must be positive")
+	}
+	if len(wordlist) == 0 {
+		wordlist = defaultWordlist()
+	}
+	if len(wordlist) == 0 {
+		return nil, errors.New("GoPasswordUtilities: no wordlist available")
+	}
+
+	words := make([]string, wordCount)
+	for i := range words {
+		words[i] = wordlist[randIndex(len(wordlist))]
+	}
+
+	bits := float64(wordCount) * math.Log2(float64(len(wordlist)))
+	if minBits > 0 && bits < minBits {
+		return nil, ErrEntropyTooLow
+	}
+	return New(strings.Join(words, sep)), nil
+}
+
+// GeneratePronounceable builds a memorable string of the given
+// number of syllables, each an alternating consonant/vowel/consonant
+// triplet. It returns ErrEntropyTooLow if minBits is positive and the
+// result doesn't reach it.
+func GeneratePronounceable(syllables int, minBits float64) (*Password, error) {
+	if syllables <= 0 {
+		return nil, errors.New("GoPasswordUtilities: syllables must be positive")
+	}
+
+	var sb strings.Builder
+	for i := 0; i < syllables; i++ {
+		sb.WriteByte(randChar(consonants))
+		sb.WriteByte(randChar(vowels))
+		sb.WriteByte(randChar(consonants))
+	}
+
+	syllablePool := float64(len(consonants) * len(vowels) * len(consonants))
+	bits := float64(syllables) * math.Log2(syllablePool)
+	if minBits > 0 && bits < minBits {
+		return nil, ErrEntropyTooLow
+	}
+	return New(sb.String()), nil
+}
+
+// GenerateFromPattern expands a KeePass-style pattern into a
+// password: L, l, d, s and a expand to a random upper-case letter,
+// lower-case letter, digit, special character or any character
+// respectively; any other rune is copied through literally. It
+// returns ErrEntropyTooLow if minBits is positive and the result
+// doesn't reach it.
+func GenerateFromPattern(pattern string, minBits float64) (*Password, error) {
+	var sb strings.Builder
+	pool := 1.0
+
+	for _, r := range pattern {
+		switch r {
+		case 'L':
+			sb.WriteByte(randChar(upperAlphabet))
+			pool *= float64(len(upperAlphabet))
+		case 'l':
+			sb.WriteByte(randChar(lowerAlphabet))
+			pool *= float64(len(lowerAlphabet))
+		case 'd':
+			sb.WriteByte(randChar(digitAlphabet))
+			pool *= float64(len(digitAlphabet))
+		case 's':
+			sb.WriteByte(randChar(specialAlphabet))
+			pool *= float64(len(specialAlphabet))
+		case 'a':
+			sb.WriteByte(randChar(characters))
+			pool *= float64(len(characters))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	bits := math.Log2(pool)
+	if minBits > 0 && bits < minBits {
+		return nil, ErrEntropyTooLow
+	}
+	return New(sb.String()), nil
+}
+
+// randIndex returns an index in [0, n) drawn uniformly via
+// crypto/rand.
+func randIndex(n int) int {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0
+	}
+	return int(i.Int64())
+}
@@ -0,0 +1,80 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassphraseDefaultWordlist(t *testing.T) {
+	p, err := GeneratePassphrase(4, "-", nil, 0)
+	if err != nil {
+		t.Fatalf("GeneratePassphrase: %v", err)
+	}
+	if got := len(strings.Split(p.Pass, "-")); got != 4 {
+		t.Fatalf("expected 4 words, got %d (%q)", got, p.Pass)
+	}
+}
+
+func TestGeneratePassphraseEntropyTooLow(t *testing.T) {
+	if _, err := GeneratePassphrase(1, "-", []string{"a", "b"}, 100); err != ErrEntropyTooLow {
+		t.Fatalf("expected ErrEntropyTooLow, got %v", err)
+	}
+}
+
+func TestGeneratePassphraseInvalidWordCount(t *testing.T) {
+	if _, err := GeneratePassphrase(0, "-", nil, 0); err == nil {
+		t.Fatalf("expected error for zero wordCount")
+	}
+	if _, err := GeneratePassphrase(-1, "-", nil, 0); err == nil {
+		t.Fatalf("expected error for negative wordCount")
+	}
+}
+
+func TestGeneratePronounceable(t *testing.T) {
+	p, err := GeneratePronounceable(3, 0)
+	if err != nil {
+		t.Fatalf("GeneratePronounceable: %v", err)
+	}
+	if p.Length != 9 {
+		t.Fatalf("expected length 9 for 3 syllables, got %d", p.Length)
+	}
+}
+
+func TestGeneratePronounceableInvalidSyllables(t *testing.T) {
+	if _, err := GeneratePronounceable(0, 0); err == nil {
+		t.Fatalf("expected error for zero syllables")
+	}
+	if _, err := GeneratePronounceable(-3, 0); err == nil {
+		t.Fatalf("expected error for negative syllables")
+	}
+}
+
+func TestGenerateFromPattern(t *testing.T) {
+	p, err := GenerateFromPattern("Lllddss", 0)
+	if err != nil {
+		t.Fatalf("GenerateFromPattern: %v", err)
+	}
+	if p.Length != 7 {
+		t.Fatalf("expected length 7, got %d", p.Length)
+	}
+}
+
+func TestGenerateFromPatternEntropyTooLow(t *testing.T) {
+	if _, err := GenerateFromPattern("d", 100); err != ErrEntropyTooLow {
+		t.Fatalf("expected ErrEntropyTooLow, got %v", err)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Scheme identifies which adaptive hashing algorithm produced an
+// encoded hash string.
+type Scheme string
+
+// Supported hashing schemes.
+const (
+	SchemeBcrypt   Scheme = "bcrypt"
+	SchemeArgon2id Scheme = "argon2id"
+	SchemePBKDF2   Scheme = "pbkdf2-sha256"
+)
+
+// Params carries the cost parameters for whichever Scheme is in use.
+// Only the fields relevant to the chosen Scheme are consulted.
+type Params struct {
+	// Cost is the bcrypt work factor. Ignored by other schemes.
+	Cost int
+
+	// Memory, Time and Threads are the argon2id cost parameters.
+	// Ignored by other schemes.
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+
+	// Iterations is the PBKDF2-SHA256 iteration count. Ignored by
+	// other schemes.
+	Iterations int
+
+	// KeyLen is the derived key length in bytes, used by argon2id
+	// and PBKDF2.
+	KeyLen int
+
+	// SaltLen is the salt length in bytes, used by argon2id and
+	// PBKDF2.
+	SaltLen int
+}
+
+// DefaultBcryptParams uses bcrypt's recommended default cost.
+var DefaultBcryptParams = Params{Cost: bcrypt.DefaultCost}
+
+// DefaultArgon2idParams mirrors the argon2 package's own suggested
+// defaults for interactive logins.
+var DefaultArgon2idParams = Params{Memory: 64 * 1024, Time: 3, Threads: 2, KeyLen: 32, SaltLen: 16}
+
+// DefaultPBKDF2Params uses a conservative iteration count for
+// PBKDF2-SHA256 as of 2023 OWASP guidance.
+var DefaultPBKDF2Params = Params{Iterations: 600000, KeyLen: 32, SaltLen: 16}
+
+// Errors returned while encoding or decoding PHC-style hash strings.
+var (
+	ErrUnknownScheme = errors.New("GoPasswordUtilities: unknown hash scheme")
+	ErrInvalidHash   = errors.New("GoPasswordUtilities: invalid encoded hash")
+)
+
+// Hash derives an encoded, self-describing hash of p.Pass using scheme
+// and params. The returned string carries the algorithm, cost
+// parameters and salt, e.g.
+// "$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>", so Verify and
+// NeedsRehash can later be called with nothing but the encoded string.
+func (p *Password) Hash(scheme Scheme, params Params) (string, error) {
+	switch scheme {
+	case SchemeBcrypt:
+		return hashBcrypt(p.Pass, params)
+	case SchemeArgon2id:
+		return hashArgon2id(p.Pass, params)
+	case SchemePBKDF2:
+		return hashPBKDF2(p.Pass, params)
+	default:
+		return "", ErrUnknownScheme
+	}
+}
+
+// Verify reports whether plaintext matches the password captured in
+// encoded. The scheme is read from encoded itself, and comparison is
+// constant-time.
+func Verify(encoded, plaintext string) (bool, error) {
+	switch schemeOf(encoded) {
+	case SchemeBcrypt:
+		return verifyBcrypt(encoded, plaintext)
+	case SchemeArgon2id:
+		return verifyArgon2id(encoded, plaintext)
+	case SchemePBKDF2:
+		return verifyPBKDF2(encoded, plaintext)
+	default:
+		return false, ErrUnknownScheme
+	}
+}
+
+// NeedsRehash reports whether encoded was produced with cost
+// parameters weaker than target, so callers can transparently
+// upgrade a stored hash the next time its owner authenticates
+// successfully.
+func NeedsRehash(encoded string, target Params) bool {
+	switch schemeOf(encoded) {
+	case SchemeBcrypt:
+		cost, err := bcrypt.Cost([]byte(encoded))
+		if err != nil {
+			return true
+		}
+		return cost < target.Cost
+	case SchemeArgon2id:
+		_, _, mem, t, threads, _, err := decodeArgon2id(encoded)
+		if err != nil {
+			return true
+		}
+		return mem < target.Memory || t < target.Time || threads < target.Threads
+	case SchemePBKDF2:
+		_, iterations, _, _, err := decodePBKDF2(encoded)
+		if err != nil {
+			return true
+		}
+		return iterations < target.Iterations
+	default:
+		return true
+	}
+}
+
+// schemeOf identifies the Scheme that produced encoded, returning ""
+// if it isn't recognized.
+func schemeOf(encoded string) Scheme {
+	switch {
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		return SchemeBcrypt
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return SchemeArgon2id
+	case strings.HasPrefix(encoded, "$pbkdf2-sha256$"):
+		return SchemePBKDF2
+	default:
+		return ""
+	}
+}
+
+func hashBcrypt(plaintext string, params Params) (string, error) {
+	cost := params.Cost
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(plaintext), cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func verifyBcrypt(encoded, plaintext string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(plaintext))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return false, err
+}
+
+func hashArgon2id(plaintext string, params Params) (string, error) {
+	salt := make([]byte, saltLenOrDefault(params.SaltLen))
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	memory := params.Memory
+	if memory == 0 {
+		memory = DefaultArgon2idParams.Memory
+	}
+	time := params.Time
+	if time == 0 {
+		time = DefaultArgon2idParams.Time
+	}
+	threads := params.Threads
+	if threads == 0 {
+		threads = DefaultArgon2idParams.Threads
+	}
+	keyLen := uint32(keyLenOrDefault(params.KeyLen))
+	hash := argon2.IDKey([]byte(plaintext), salt, time, memory, threads, keyLen)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, memory, time, threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash)), nil
+}
+
+func verifyArgon2id(encoded, plaintext string) (bool, error) {
+	salt, hash, memory, time, threads, _, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+	candidate := argon2.IDKey([]byte(plaintext), salt, time, memory, threads, uint32(len(hash)))
+	return subtle.ConstantTimeCompare(candidate, hash) == 1, nil
+}
+
+// decodeArgon2id parses a "$argon2id$v=..$m=..,t=..,p=..$salt$hash"
+// string into its component parts.
+func decodeArgon2id(encoded string) (salt, hash []byte, memory, time uint32, threads uint8, version int, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, 0, 0, 0, 0, ErrInvalidHash
+	}
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, 0, 0, 0, 0, ErrInvalidHash
+	}
+	var p int
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &p); err != nil {
+		return nil, nil, 0, 0, 0, 0, ErrInvalidHash
+	}
+	threads = uint8(p)
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, nil, 0, 0, 0, 0, ErrInvalidHash
+	}
+	if hash, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, nil, 0, 0, 0, 0, ErrInvalidHash
+	}
+	return salt, hash, memory, time, threads, version, nil
+}
+
+func hashPBKDF2(plaintext string, params Params) (string, error) {
+	iterations := params.Iterations
+	if iterations == 0 {
+		iterations = DefaultPBKDF2Params.Iterations
+	}
+	salt := make([]byte, saltLenOrDefault(params.SaltLen))
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	keyLen := keyLenOrDefault(params.KeyLen)
+	hash := pbkdf2.Key([]byte(plaintext), salt, iterations, keyLen, sha256.New)
+
+	return fmt.Sprintf("$pbkdf2-sha256$i=%d$%s$%s",
+		iterations,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash)), nil
+}
+
+func verifyPBKDF2(encoded, plaintext string) (bool, error) {
+	salt, iterations, hash, _, err := decodePBKDF2(encoded)
+	if err != nil {
+		return false, err
+	}
+	candidate := pbkdf2.Key([]byte(plaintext), salt, iterations, len(hash), sha256.New)
+	return subtle.ConstantTimeCompare(candidate, hash) == 1, nil
+}
+
+// decodePBKDF2 parses a "$pbkdf2-sha256$i=..$salt$hash" string into
+// its component parts.
+func decodePBKDF2(encoded string) (salt []byte, iterations int, hash []byte, saltLen int, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != "pbkdf2-sha256" {
+		return nil, 0, nil, 0, ErrInvalidHash
+	}
+	iterStr := strings.TrimPrefix(parts[2], "i=")
+	if iterStr == parts[2] {
+		return nil, 0, nil, 0, ErrInvalidHash
+	}
+	if iterations, err = strconv.Atoi(iterStr); err != nil {
+		return nil, 0, nil, 0, ErrInvalidHash
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+		return nil, 0, nil, 0, ErrInvalidHash
+	}
+	if hash, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, 0, nil, 0, ErrInvalidHash
+	}
+	return salt, iterations, hash, len(salt), nil
+}
+
+func saltLenOrDefault(n int) int {
+	if n <= 0 {
+		return 16
+	}
+	return n
+}
+
+func keyLenOrDefault(n int) int {
+	if n <= 0 {
+		return 32
+	}
+	return n
+}
@@ -0,0 +1,89 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import "testing"
+
+func TestHashVerifyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		scheme Scheme
+		params Params
+	}{
+		{"bcrypt", SchemeBcrypt, DefaultBcryptParams},
+		{"argon2id", SchemeArgon2id, DefaultArgon2idParams},
+		{"pbkdf2", SchemePBKDF2, DefaultPBKDF2Params},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New("correct horse battery staple")
+			encoded, err := p.Hash(tt.scheme, tt.params)
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+
+			ok, err := Verify(encoded, "correct horse battery staple")
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if !ok {
+				t.Fatal("Verify: expected match for correct password")
+			}
+
+			ok, err = Verify(encoded, "wrong password")
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+			if ok {
+				t.Fatal("Verify: expected no match for wrong password")
+			}
+		})
+	}
+}
+
+func TestHashArgon2idZeroParamsDoesNotPanic(t *testing.T) {
+	p := New("correct horse battery staple")
+	encoded, err := p.Hash(SchemeArgon2id, Params{})
+	if err != nil {
+		t.Fatalf("Hash with zero Params: %v", err)
+	}
+
+	ok, err := Verify(encoded, "correct horse battery staple")
+	if err != nil || !ok {
+		t.Fatalf("Verify: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	p := New("correct horse battery staple")
+	encoded, err := p.Hash(SchemeArgon2id, Params{Memory: 16 * 1024, Time: 1, Threads: 1, KeyLen: 32})
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+
+	if NeedsRehash(encoded, Params{Memory: 16 * 1024, Time: 1, Threads: 1}) {
+		t.Fatal("expected no rehash needed against equal params")
+	}
+	if !NeedsRehash(encoded, DefaultArgon2idParams) {
+		t.Fatal("expected rehash needed against stronger params")
+	}
+}
+
+func TestVerifyUnknownScheme(t *testing.T) {
+	if _, err := Verify("not-a-real-hash", "anything"); err != ErrUnknownScheme {
+		t.Fatalf("expected ErrUnknownScheme, got %v", err)
+	}
+}
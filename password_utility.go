@@ -18,23 +18,18 @@
 package GoPasswordUtilities
 
 import (
-	"bufio"
 	"bytes"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha256"
 	"crypto/sha512"
-	"errors"
 	"fmt"
 	"log"
-	"os"
-	"regexp"
-	"strings"
+	"math"
 )
 
 const (
-	characters    = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!@#$%^&*()-_=+,.?/:;{}[]~"
-	wordsLocation = "/usr/share/dict/words"
+	characters = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!@#$%^&*()-_=+,.?/:;{}[]~"
 )
 
 var (
@@ -54,6 +49,7 @@ type Password struct {
 type PasswordComplexity struct {
 	Length          int
 	Score           int
+	Entropy         float64
 	ContainsUpper   bool
 	ContainsLower   bool
 	ContainsNumber  bool
@@ -87,19 +83,25 @@ func GeneratePassword(length int) *Password {
 	return New(passwordBuffer.String())
 }
 
+// veryStrongPolicy requires all four character classes.
+var veryStrongPolicy = Policy{MinLength: 8, Required: []CharClass{Lower, Upper, Digit, Special}}
+
+// veryStrongEntropyThreshold is the bit count a password must reach
+// to be considered "Very Strong" per EntropyRating.
+const veryStrongEntropyThreshold = 128.0
+
 // GenerateVeryStrongPassword will generate a "Very Strong" password.
+// Unlike the old implementation, which looped regenerating passwords
+// until one happened to score a 4 on the class-count heuristic, this
+// targets veryStrongEntropyThreshold directly: length is bumped up
+// front if necessary so the result is guaranteed to clear the bar on
+// the first try.
 func GenerateVeryStrongPassword(length int) *Password {
-	for {
-		p := GeneratePassword(length)
-		pc, err := ProcessPassword(p)
-		if err != nil {
-			log.Fatalln(err)
-		}
-
-		if pc.Score == 4 {
-			return p
-		}
+	minLength := int(math.Ceil(veryStrongEntropyThreshold / math.Log2(float64(len(characters)))))
+	if length < minLength {
+		length = minLength
 	}
+	return veryStrongPolicy.GenerateCompliant(length)
 }
 
 // getRandomBytes will generate random bytes.  This is for internal
@@ -170,63 +172,42 @@ func (p *Password) GetLength() int {
 	return p.Length
 }
 
-// ProcessPassword will parse the password and populate the PasswordComplexity struct.
+// ProcessPassword will parse the password and populate the
+// PasswordComplexity struct. It's a thin wrapper over DefaultPolicy
+// for callers who don't need to configure their own Policy.
 func ProcessPassword(p *Password) (*PasswordComplexity, error) {
-	c := &PasswordComplexity{}
-	matchLower := regexp.MustCompile(`[a-z]`)
-	matchUpper := regexp.MustCompile(`[A-Z]`)
-	matchNumber := regexp.MustCompile(`[0-9]`)
-	matchSpecial := regexp.MustCompile(`[\!\@\#\$\%\^\&\*\(\\\)\-_\=\+\,\.\?\/\:\;\{\}\[\]~]`)
-
-	if p.Length < 8 {
-		return nil, errors.New("ERROR: password isn't long enough for evaluation")
+	report, err := DefaultPolicy.Evaluate(p)
+	if err != nil {
+		return nil, err
 	}
 
-	c.Length = p.Length
-
-	if matchLower.MatchString(p.Pass) {
-		c.ContainsLower = true
+	c := &PasswordComplexity{
+		Length:          report.Length,
+		Entropy:         Entropy(p),
+		ContainsLower:   report.ContainsLower,
+		ContainsUpper:   report.ContainsUpper,
+		ContainsNumber:  report.ContainsNumber,
+		ContainsSpecial: report.ContainsSpecial,
+	}
+	if c.ContainsLower {
 		c.Score++
 	}
-	if matchUpper.MatchString(p.Pass) {
-		c.ContainsUpper = true
+	if c.ContainsUpper {
 		c.Score++
 	}
-	if matchNumber.MatchString(p.Pass) {
-		c.ContainsNumber = true
+	if c.ContainsNumber {
 		c.Score++
 	}
-	if matchSpecial.MatchString(p.Pass) {
-		c.ContainsSpecial = true
+	if c.ContainsSpecial {
 		c.Score++
 	}
-	if searchDict(p.Pass) {
+	if _, _, ok := defaultDictionary.Check(p.Pass); ok {
 		c.DictionaryBased = true
 		c.Score--
 	}
 	return c, nil
 }
 
-// searchDict will search the words list for an occurance of the
-// given word.  Requires wamerican || wbritish || wordlist || words
-// to be installed.
-func searchDict(word string) bool {
-	file, err := os.Open(wordsLocation)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		if strings.Contains(strings.ToLower(scanner.Text()), word) {
-			break
-			return true
-		}
-	}
-	return false
-}
-
 // GetScore will provide the score of the password.
 func (c *PasswordComplexity) GetScore() int {
 	return c.Score
@@ -0,0 +1,246 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"unicode"
+)
+
+// CharClass identifies a class of characters a Policy can require at
+// least one occurrence of.
+type CharClass int
+
+// Supported character classes.
+const (
+	Lower CharClass = iota
+	Upper
+	Digit
+	Special
+)
+
+const (
+	lowerAlphabet   = "abcdefghijklmnopqrstuvwxyz"
+	upperAlphabet   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitAlphabet   = "0123456789"
+	specialAlphabet = "!@#$%^&*()-_=+,.?/:;{}[]~"
+)
+
+// alphabetFor returns the alphabet backing a CharClass.
+func alphabetFor(c CharClass) string {
+	switch c {
+	case Lower:
+		return lowerAlphabet
+	case Upper:
+		return upperAlphabet
+	case Digit:
+		return digitAlphabet
+	case Special:
+		return specialAlphabet
+	default:
+		return characters
+	}
+}
+
+// Policy describes the complexity rules a password must satisfy.
+type Policy struct {
+	// MinLength is the shortest acceptable password. Zero means no
+	// minimum is enforced.
+	MinLength int
+
+	// MaxLength is the longest acceptable password. Zero means no
+	// maximum is enforced.
+	MaxLength int
+
+	// MinUniqueChars is the fewest distinct runes the password must
+	// contain. Zero means uniqueness isn't checked.
+	MinUniqueChars int
+
+	// Required lists the character classes that must each appear
+	// at least once.
+	Required []CharClass
+
+	// ForbidWhitespace rejects passwords containing whitespace
+	// runes.
+	ForbidWhitespace bool
+}
+
+// DefaultPolicy mirrors the complexity rules ProcessPassword has
+// always enforced: an eight character minimum with no required
+// classes beyond what contributes to the legacy Score.
+var DefaultPolicy = Policy{MinLength: 8}
+
+// Report carries the findings from evaluating a Password against a
+// Policy.
+type Report struct {
+	Length          int
+	UniqueChars     int
+	ContainsLower   bool
+	ContainsUpper   bool
+	ContainsNumber  bool
+	ContainsSpecial bool
+}
+
+// Typed errors returned by Policy.Evaluate.
+var (
+	ErrMinLength       = errors.New("GoPasswordUtilities: password is shorter than the policy's minimum length")
+	ErrMaxLength       = errors.New("GoPasswordUtilities: password is longer than the policy's maximum length")
+	ErrComplexity      = errors.New("GoPasswordUtilities: password is missing a required character class")
+	ErrNotEnoughUnique = errors.New("GoPasswordUtilities: password doesn't contain enough unique characters")
+	ErrWhitespace      = errors.New("GoPasswordUtilities: password contains whitespace")
+)
+
+// Evaluate checks p against the policy and returns a Report
+// describing what was found. A non-nil error identifies the first
+// policy rule p failed to satisfy; the Report is still populated in
+// that case so callers can explain the failure to a user.
+func (policy Policy) Evaluate(p *Password) (*Report, error) {
+	report := &Report{Length: p.Length}
+
+	unique := make(map[rune]struct{})
+	hasWhitespace := false
+	for _, r := range p.Pass {
+		unique[r] = struct{}{}
+		switch {
+		case unicode.IsLower(r):
+			report.ContainsLower = true
+		case unicode.IsUpper(r):
+			report.ContainsUpper = true
+		case unicode.IsDigit(r):
+			report.ContainsNumber = true
+		case unicode.IsSpace(r):
+			hasWhitespace = true
+		default:
+			report.ContainsSpecial = true
+		}
+	}
+	report.UniqueChars = len(unique)
+
+	if policy.MinLength > 0 && p.Length < policy.MinLength {
+		return report, ErrMinLength
+	}
+	if policy.MaxLength > 0 && p.Length > policy.MaxLength {
+		return report, ErrMaxLength
+	}
+	if policy.ForbidWhitespace && hasWhitespace {
+		return report, ErrWhitespace
+	}
+	for _, class := range policy.Required {
+		switch class {
+		case Lower:
+			if !report.ContainsLower {
+				return report, ErrComplexity
+			}
+		case Upper:
+			if !report.ContainsUpper {
+				return report, ErrComplexity
+			}
+		case Digit:
+			if !report.ContainsNumber {
+				return report, ErrComplexity
+			}
+		case Special:
+			if !report.ContainsSpecial {
+				return report, ErrComplexity
+			}
+		}
+	}
+	if policy.MinUniqueChars > 0 && report.UniqueChars < policy.MinUniqueChars {
+		return report, ErrNotEnoughUnique
+	}
+	return report, nil
+}
+
+// GenerateCompliant produces a password of at least the given length
+// that is guaranteed to satisfy the policy. It draws at least one
+// character from each required class, fills the remainder from the
+// union of all required alphabets (or the full character set if none
+// are required), and shuffles the result so the required characters
+// aren't predictably placed. length is raised to policy.MinLength,
+// policy.MinUniqueChars and len(policy.Required) first if necessary,
+// since a password shorter than any of those could never satisfy
+// Evaluate, and capped at policy.MaxLength if one is set.
+func (policy Policy) GenerateCompliant(length int) *Password {
+	if length < len(policy.Required) {
+		length = len(policy.Required)
+	}
+	if length < policy.MinLength {
+		length = policy.MinLength
+	}
+	if length < policy.MinUniqueChars {
+		length = policy.MinUniqueChars
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		length = policy.MaxLength
+	}
+
+	pool := ""
+	for _, class := range policy.Required {
+		pool += alphabetFor(class)
+	}
+	if pool == "" {
+		pool = characters
+	}
+
+	result := make([]byte, length)
+	seen := make(map[byte]bool)
+	for i, class := range policy.Required {
+		result[i] = randChar(alphabetFor(class))
+		seen[result[i]] = true
+	}
+	for i := len(policy.Required); i < length; i++ {
+		c := randChar(pool)
+		if seen[c] && len(seen) < policy.MinUniqueChars {
+			var unused []byte
+			for j := 0; j < len(pool); j++ {
+				if !seen[pool[j]] {
+					unused = append(unused, pool[j])
+				}
+			}
+			if len(unused) > 0 {
+				c = unused[randIndex(len(unused))]
+			}
+		}
+		result[i] = c
+		seen[c] = true
+	}
+
+	shuffleBytes(result)
+	return New(string(result))
+}
+
+// randChar returns a single byte drawn uniformly from alphabet using
+// crypto/rand.
+func randChar(alphabet string) byte {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+	if err != nil {
+		return alphabet[0]
+	}
+	return alphabet[n.Int64()]
+}
+
+// shuffleBytes performs a Fisher-Yates shuffle using crypto/rand.
+func shuffleBytes(b []byte) {
+	for i := len(b) - 1; i > 0; i-- {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			continue
+		}
+		j := n.Int64()
+		b[i], b[j] = b[j], b[i]
+	}
+}
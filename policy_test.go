@@ -0,0 +1,104 @@
+// Copyright 2014 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package GoPasswordUtilities
+
+import "testing"
+
+func TestPolicyEvaluate(t *testing.T) {
+	policy := Policy{MinLength: 8, Required: []CharClass{Lower, Upper, Digit, Special}}
+
+	if _, err := policy.Evaluate(New("short")); err != ErrMinLength {
+		t.Fatalf("expected ErrMinLength, got %v", err)
+	}
+	if _, err := policy.Evaluate(New("alllowercase")); err != ErrComplexity {
+		t.Fatalf("expected ErrComplexity, got %v", err)
+	}
+	if _, err := policy.Evaluate(New("Aa1!aaaa")); err != nil {
+		t.Fatalf("expected compliant password to pass, got %v", err)
+	}
+}
+
+func TestPolicyEvaluateMinUniqueChars(t *testing.T) {
+	policy := Policy{MinLength: 8, MinUniqueChars: 6}
+	if _, err := policy.Evaluate(New("aaaaaaaa")); err != ErrNotEnoughUnique {
+		t.Fatalf("expected ErrNotEnoughUnique, got %v", err)
+	}
+}
+
+func TestGenerateCompliant(t *testing.T) {
+	policy := Policy{MinLength: 8, Required: []CharClass{Lower, Upper, Digit, Special}}
+	p := policy.GenerateCompliant(16)
+	if p.Length != 16 {
+		t.Fatalf("expected length 16, got %d", p.Length)
+	}
+	if _, err := policy.Evaluate(p); err != nil {
+		t.Fatalf("generated password failed its own policy: %v", err)
+	}
+}
+
+// TestGenerateCompliantShortLength guards against regenerating a
+// password too short to fit one character from every required
+// class.
+func TestGenerateCompliantShortLength(t *testing.T) {
+	policy := Policy{Required: []CharClass{Lower, Upper, Digit, Special}}
+	p := policy.GenerateCompliant(2)
+	if p.Length < len(policy.Required) {
+		t.Fatalf("expected length to be raised to at least %d, got %d", len(policy.Required), p.Length)
+	}
+	if _, err := policy.Evaluate(p); err != nil {
+		t.Fatalf("generated password failed its own policy: %v (pass=%q)", err, p.Pass)
+	}
+}
+
+// TestGenerateCompliantBelowMinLength guards against the requested
+// length satisfying len(Required) but still falling short of
+// MinLength, which Evaluate would then reject.
+func TestGenerateCompliantBelowMinLength(t *testing.T) {
+	policy := Policy{MinLength: 8, Required: []CharClass{Lower, Upper}}
+	p := policy.GenerateCompliant(2)
+	if p.Length < policy.MinLength {
+		t.Fatalf("expected length to be raised to at least MinLength %d, got %d", policy.MinLength, p.Length)
+	}
+	if _, err := policy.Evaluate(p); err != nil {
+		t.Fatalf("generated password failed its own policy: %v (pass=%q)", err, p.Pass)
+	}
+}
+
+// TestGenerateCompliantBelowMinUniqueChars guards against the
+// requested length satisfying MinLength and len(Required) but still
+// being too short to ever reach MinUniqueChars distinct runes, which
+// Evaluate would then reject.
+func TestGenerateCompliantBelowMinUniqueChars(t *testing.T) {
+	policy := Policy{MinLength: 8, MinUniqueChars: 6, Required: []CharClass{Lower, Upper, Digit, Special}}
+	p := policy.GenerateCompliant(2)
+	if p.Length < policy.MinUniqueChars {
+		t.Fatalf("expected length to be raised to at least MinUniqueChars %d, got %d", policy.MinUniqueChars, p.Length)
+	}
+	if _, err := policy.Evaluate(p); err != nil {
+		t.Fatalf("generated password failed its own policy: %v (pass=%q)", err, p.Pass)
+	}
+}
+
+// TestGenerateCompliantUnknownCharClass guards against a Policy
+// constructed with a CharClass value outside the four predefined
+// constants: alphabetFor must fall back to the full character set
+// instead of returning "", which would otherwise make randChar panic.
+func TestGenerateCompliantUnknownCharClass(t *testing.T) {
+	policy := Policy{Required: []CharClass{CharClass(99)}}
+	p := policy.GenerateCompliant(4)
+	if p.Length != 4 {
+		t.Fatalf("expected length 4, got %d", p.Length)
+	}
+}